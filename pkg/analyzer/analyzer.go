@@ -5,16 +5,37 @@ import (
 	"go/ast"
 	"go/token"
 	"go/types"
+	"path/filepath"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
 )
 
 const (
 	ForbidImproperConversions = "forbid-improper-conversions"
+	DefaultUnit               = "default-unit"
+	DurationTypes             = "duration-types"
+	Mode                      = "mode"
 )
 
+const defaultUnitDefault = "time.Second"
+
+// Recognized values of the -mode flag.
+const (
+	modeAST = "ast"
+	modeSSA = "ssa"
+)
+
+// durationDocComment marks a named integer type as duration-like when it appears on its
+// TypeSpec (or the enclosing GenDecl, for `type X int64` single-spec declarations).
+const durationDocComment = "durationlint:duration"
+
 var (
 	fForbidImproperConversions bool
+	fDefaultUnit               string
+	fDurationTypes             []string
+	fMode                      string
 )
 
 func init() {
@@ -26,17 +47,48 @@ func registerFlags() {
 		&fForbidImproperConversions, ForbidImproperConversions, false,
 		"report errors on conversion of integers via `time.Duration()` without multiplying them by proper units like `time.Second`",
 	)
+	Analyzer.Flags.StringVar(
+		&fDefaultUnit, DefaultUnit, defaultUnitDefault,
+		"unit suggested in autofixes when multiplying a bare `time.Duration()` conversion, e.g. `time.Second` (bare integers are always multiplied by `time.Nanosecond`)",
+	)
+	Analyzer.Flags.Var(
+		(*stringListFlag)(&fDurationTypes), DurationTypes,
+		"additional named type to treat as `time.Duration` (e.g. `mypkg.Timeout`); may be repeated",
+	)
+	Analyzer.Flags.StringVar(
+		&fMode, Mode, modeAST,
+		"analysis strategy to use: `ast` (default, syntactic) or `ssa` (data-flow based, via golang.org/x/tools/go/ssa)",
+	)
 }
 
 // reset flags to default values; useful for testing
 func resetFlags() {
 	fForbidImproperConversions = false
+	fDefaultUnit = defaultUnitDefault
+	fDurationTypes = nil
+	fMode = modeAST
+	configCache = map[string]Config{}
+}
+
+// stringListFlag implements flag.Value over a []string, appending each occurrence of the
+// flag instead of overwriting it, so `-duration-types` can be repeated on the command line.
+type stringListFlag []string
+
+func (f *stringListFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringListFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
 }
 
 var Analyzer = &analysis.Analyzer{
-	Name: "durationlint",
-	Doc:  "disallows usage of untyped literals and constants as time.Duration",
-	Run:  run,
+	Name:      "durationlint",
+	Doc:       "disallows usage of untyped literals and constants as time.Duration",
+	Run:       run,
+	FactTypes: []analysis.Fact{new(durationFact), new(durationTypeFact)},
+	Requires:  []*analysis.Analyzer{buildssa.Analyzer},
 }
 
 // improperDurationContext is a helper to track context of `time.Duration(int)` conversions in a
@@ -61,6 +113,11 @@ type improperDurationContext struct {
 	// Diagnostics which may be reported if no proper child is found or this is not
 	// a multiplication
 	deferredImproperDurationDiagnostics []*analysis.Diagnostic
+	// funcResults holds the result type exprs of the innermost enclosing *ast.FuncDecl or
+	// *ast.FuncLit, so a *ast.ReturnStmt encountered while this node is current can look up
+	// which type each of its operands must satisfy. Inherited unchanged from the parent
+	// frame except at a FuncDecl/FuncLit node itself.
+	funcResults []ast.Expr
 }
 
 func (c *improperDurationContext) isProperDuration() bool {
@@ -81,9 +138,11 @@ func (c *improperDurationContext) isImproperDuration() bool {
 
 // stack must be nonempty for any of its methods aside from `PushCurrent` to be called
 type improperDurationContextStack struct {
-	pass     *analysis.Pass
-	sentinel improperDurationContext
-	slice    []improperDurationContext
+	pass      *analysis.Pass
+	config    Config
+	userTypes map[string]bool
+	sentinel  improperDurationContext
+	slice     []improperDurationContext
 }
 
 func (s *improperDurationContextStack) PushCurrent(node ast.Node) {
@@ -96,15 +155,54 @@ func (s *improperDurationContextStack) PushCurrent(node ast.Node) {
 	}
 	isDurationExpr := false
 	if isExpr {
-		isDurationExpr = isDurationType(s.pass.TypesInfo.TypeOf(expr))
+		isDurationExpr = isDurationType(s.pass, s.config, s.userTypes, s.pass.TypesInfo.TypeOf(expr))
+	}
+
+	funcResults := s.topFuncResults()
+	switch fn := node.(type) {
+	case *ast.FuncDecl:
+		funcResults = resultTypeExprs(fn.Type)
+	case *ast.FuncLit:
+		funcResults = resultTypeExprs(fn.Type)
 	}
+
 	s.slice = append(s.slice, improperDurationContext{
 		node:                 node,
 		isMultiplicationExpr: isMultiplicationExpr,
 		isDurationType:       isDurationExpr,
+		funcResults:          funcResults,
 	})
 }
 
+// topFuncResults returns the funcResults of whatever node is current before a new frame is
+// pushed onto the stack, i.e. the innermost enclosing FuncDecl/FuncLit's result types.
+func (s *improperDurationContextStack) topFuncResults() []ast.Expr {
+	if len(s.slice) == 0 {
+		return s.sentinel.funcResults
+	}
+	return s.slice[len(s.slice)-1].funcResults
+}
+
+// resultTypeExprs flattens ft's result fields into one type expr per logical return value,
+// expanding multi-name fields (e.g. `(a, b int)` becomes two entries, both pointing at the
+// same `int` expr) so its length and order line up with a ReturnStmt's Results.
+func resultTypeExprs(ft *ast.FuncType) []ast.Expr {
+	if ft.Results == nil {
+		return nil
+	}
+	var results []ast.Expr
+	for _, field := range ft.Results.List {
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			results = append(results, field.Type)
+		}
+	}
+	return results
+}
+
 func (s *improperDurationContextStack) PopCurrent() {
 	current := s.current()
 	parent := s.parent()
@@ -169,13 +267,33 @@ func (s *improperDurationContextStack) Finish() {
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
+	if fMode == modeSSA {
+		return runSSA(pass)
+	}
+
+	userTypes := collectUserDurationTypes(pass)
+
+	// Export a durationFact for every duration-returning function before diagnosing any
+	// file, so that a call to a function declared later in the package (or in another
+	// file of the same package) is already visible to usesImproperDurationFact.
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok {
+				exportDurationFact(pass, userTypes, funcDecl)
+			}
+		}
+	}
+
 	for _, file := range pass.Files {
 		// NOTE: as struct and function call expressions can be nested in any
 		// other assignment and call expressions, we want to always return true
 		// to continue descending the tree
 
+		filename := pass.Fset.Position(file.Pos()).Filename
 		stack := improperDurationContextStack{
-			pass: pass,
+			pass:      pass,
+			config:    configForDir(filepath.Dir(filename)),
+			userTypes: userTypes,
 		}
 		defer stack.Finish()
 		ast.Inspect(file, func(node ast.Node) bool {
@@ -187,17 +305,28 @@ func run(pass *analysis.Pass) (interface{}, error) {
 			}
 			switch v := node.(type) {
 			case *ast.KeyValueExpr:
-				diag := checkAssignment(pass, v.Key, v.Value)
+				// Only struct literals key their elements by field name; map and
+				// slice/array literals key them by a real expression (a map key, or an
+				// index), which the *ast.CompositeLit case below already checks against
+				// the literal's key/element type.
+				if !isStructFieldKey(pass, v.Key) {
+					return true
+				}
+				diag := checkAssignment(pass, stack.config, userTypes, v.Key, v.Value)
 				if diag != nil {
 					pass.Report(*diag)
 				}
 				return true
 
 			case *ast.AssignStmt:
-				for i := range v.Lhs {
-					diag := checkAssignment(pass, v.Lhs[i], v.Rhs[i])
-					if diag != nil {
-						pass.Report(*diag)
+				// Skip tuple assignments such as `x, err := f()` or `v, ok := m[k]`, where
+				// Rhs has a single multi-valued expression instead of one entry per Lhs.
+				if len(v.Lhs) == len(v.Rhs) {
+					for i := range v.Lhs {
+						diag := checkAssignment(pass, stack.config, userTypes, v.Lhs[i], v.Rhs[i])
+						if diag != nil {
+							pass.Report(*diag)
+						}
 					}
 				}
 				return true
@@ -207,7 +336,58 @@ func run(pass *analysis.Pass) (interface{}, error) {
 					return true
 				}
 				for _, value := range v.Values {
-					diag := checkAssignment(pass, v.Type, value)
+					diag := checkAssignment(pass, stack.config, userTypes, v.Type, value)
+					if diag != nil {
+						pass.Report(*diag)
+					}
+				}
+				return true
+
+			case *ast.ReturnStmt:
+				resultTypes := stack.current().funcResults
+				for i, result := range v.Results {
+					if i >= len(resultTypes) {
+						break
+					}
+					diag := checkAssignment(pass, stack.config, userTypes, resultTypes[i], result)
+					if diag != nil {
+						pass.Report(*diag)
+					}
+				}
+				return true
+
+			case *ast.SendStmt:
+				chanType, ok := pass.TypesInfo.TypeOf(v.Chan).Underlying().(*types.Chan)
+				if !ok {
+					return true
+				}
+				diag := checkAssignmentType(pass, stack.config, userTypes, chanType.Elem(), v.Value)
+				if diag != nil {
+					pass.Report(*diag)
+				}
+				return true
+
+			case *ast.CompositeLit:
+				elemType := compositeLitElemType(pass, v)
+				keyType := compositeLitKeyType(pass, v)
+				if elemType == nil && keyType == nil {
+					return true
+				}
+				for _, elt := range v.Elts {
+					value := elt
+					if kv, ok := elt.(*ast.KeyValueExpr); ok {
+						if keyType != nil {
+							diag := checkAssignmentType(pass, stack.config, userTypes, keyType, kv.Key)
+							if diag != nil {
+								pass.Report(*diag)
+							}
+						}
+						value = kv.Value
+					}
+					if elemType == nil {
+						continue
+					}
+					diag := checkAssignmentType(pass, stack.config, userTypes, elemType, value)
 					if diag != nil {
 						pass.Report(*diag)
 					}
@@ -215,19 +395,25 @@ func run(pass *analysis.Pass) (interface{}, error) {
 				return true
 
 			case *ast.CallExpr:
-				isConversion := isDurationConversion(v)
+				isConversion := isDurationConversion(pass, stack.config, userTypes, v)
 				if isConversion {
-					if !fForbidImproperConversions {
+					if !*stack.config.ForbidImproperConversions {
 						return false
 					}
-					diag := checkDurationConversionArgument(pass, v.Args[0])
+					if isIgnoredCall(stack.config, v) {
+						return true
+					}
+					diag := checkDurationConversionArgument(pass, stack.config, v)
 					if diag != nil {
 						stack.ReportImproperDurationNode(diag)
 					}
 					return true
 				} else {
+					if isIgnoredCall(stack.config, v) {
+						return true
+					}
 					for _, arg := range v.Args {
-						diag := checkArgument(pass, arg)
+						diag := checkArgument(pass, stack.config, userTypes, arg)
 						if diag != nil {
 							pass.Report(*diag)
 						}
@@ -242,22 +428,24 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	return nil, nil
 }
 
-func checkArgument(pass *analysis.Pass, v ast.Expr) *analysis.Diagnostic {
-	if !isDurationType(pass.TypesInfo.TypeOf(v)) {
+func checkArgument(pass *analysis.Pass, cfg Config, userTypes map[string]bool, v ast.Expr) *analysis.Diagnostic {
+	if !isDurationType(pass, cfg, userTypes, pass.TypesInfo.TypeOf(v)) {
 		return nil
 	}
-	if !usesIntOrUntypedConstants(pass.TypesInfo, v) {
+	if !usesIntOrUntypedConstants(pass, v) {
 		return nil
 	}
 	return &analysis.Diagnostic{
-		Pos:     v.Pos(),
-		Message: "untyped constant in time.Duration argument",
+		Pos:            v.Pos(),
+		Message:        "untyped constant in time.Duration argument",
+		SuggestedFixes: durationLiteralFixes(cfg, v),
 	}
 }
 
-func checkDurationConversionArgument(pass *analysis.Pass, arg ast.Expr) *analysis.Diagnostic {
+func checkDurationConversionArgument(pass *analysis.Pass, cfg Config, call *ast.CallExpr) *analysis.Diagnostic {
+	arg := call.Args[0]
 	argType := pass.TypesInfo.TypeOf(arg)
-	implicitInt := usesIntOrUntypedConstants(pass.TypesInfo, arg)
+	implicitInt := usesIntOrUntypedConstants(pass, arg)
 	explicitInt := isIntegerType(argType)
 	if !implicitInt && !explicitInt {
 		return nil
@@ -265,35 +453,150 @@ func checkDurationConversionArgument(pass *analysis.Pass, arg ast.Expr) *analysi
 	return &analysis.Diagnostic{
 		Pos:     arg.Pos(),
 		Message: "converting integer via time.Duration() without multiplication by proper duration",
+		SuggestedFixes: []analysis.SuggestedFix{
+			buildMultiplyFix(call, *cfg.DefaultUnit),
+		},
+	}
+}
+
+func checkAssignment(pass *analysis.Pass, cfg Config, userTypes map[string]bool, l ast.Expr, r ast.Expr) *analysis.Diagnostic {
+	return checkAssignmentType(pass, cfg, userTypes, pass.TypesInfo.TypeOf(l), r)
+}
+
+// checkAssignmentType is the types.Type-driven core of checkAssignment, split out so callers
+// that only have a types.Type to check against (a channel's element type, a slice/map
+// literal's element type) don't need an ast.Expr standing in for the left-hand side.
+func checkAssignmentType(pass *analysis.Pass, cfg Config, userTypes map[string]bool, lType types.Type, r ast.Expr) *analysis.Diagnostic {
+	if !isDurationType(pass, cfg, userTypes, lType) {
+		return nil
+	}
+	if !usesIntOrUntypedConstants(pass, r) {
+		return nil
+	}
+	return &analysis.Diagnostic{
+		Pos:            r.Pos(),
+		Message:        "untyped constant in time.Duration assignment",
+		SuggestedFixes: durationLiteralFixes(cfg, r),
 	}
 }
 
-func checkAssignment(pass *analysis.Pass, l ast.Expr, r ast.Expr) *analysis.Diagnostic {
-	lType := pass.TypesInfo.TypeOf(l)
-	if lType == nil || lType.String() != "time.Duration" {
+// compositeLitElemType returns the element type of lit's slice, array, or map type, or nil
+// for any other composite literal (notably structs, whose fields are already checked via the
+// `*ast.KeyValueExpr` case, each against its own field type rather than a single element type).
+func compositeLitElemType(pass *analysis.Pass, lit *ast.CompositeLit) types.Type {
+	litType := pass.TypesInfo.TypeOf(lit)
+	if litType == nil {
 		return nil
 	}
-	if !usesIntOrUntypedConstants(pass.TypesInfo, r) {
+	switch underlying := litType.Underlying().(type) {
+	case *types.Slice:
+		return underlying.Elem()
+	case *types.Array:
+		return underlying.Elem()
+	case *types.Map:
+		return underlying.Elem()
+	}
+	return nil
+}
+
+// compositeLitKeyType returns lit's map key type, or nil for any other composite literal
+// (slice and array literals may also key their elements, by index rather than by a typed
+// key expression, so there's nothing duration-relevant to check there).
+func compositeLitKeyType(pass *analysis.Pass, lit *ast.CompositeLit) types.Type {
+	litType := pass.TypesInfo.TypeOf(lit)
+	if litType == nil {
 		return nil
 	}
-	return &analysis.Diagnostic{Pos: r.Pos(), Message: "untyped constant in time.Duration assignment"}
+	if m, ok := litType.Underlying().(*types.Map); ok {
+		return m.Key()
+	}
+	return nil
+}
+
+// isStructFieldKey reports whether key is the field-name side of a struct literal's
+// `Field: value` element, as opposed to a map key or slice/array index — the only case in
+// which TypeOf(key) is the duration-relevant type (the field's type) rather than the key's
+// own type, or, for an index, not a typed expression at all.
+func isStructFieldKey(pass *analysis.Pass, key ast.Expr) bool {
+	ident, ok := key.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	v, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	return ok && v.IsField()
+}
+
+// durationLiteralFixes builds the autofixes offered for an untyped literal or identifier
+// used in a duration context: wrapping it in `time.Duration(...)` (unless improper
+// conversions are forbidden) and multiplying it by `time.Nanosecond`. The fixes edit the
+// expression in place, so an identifier referring to a `const` declared elsewhere is
+// wrapped at its use site rather than rewriting the declaration itself.
+func durationLiteralFixes(cfg Config, expr ast.Expr) []analysis.SuggestedFix {
+	var fixes []analysis.SuggestedFix
+	if !*cfg.ForbidImproperConversions {
+		fixes = append(fixes, buildWrapConversionFix(expr))
+	}
+	fixes = append(fixes, buildMultiplyFix(expr, "time.Nanosecond"))
+	return fixes
+}
+
+// isIgnoredCall reports whether call's callee, printed as written (e.g. "time.Duration" or
+// "myutil.MustDuration"), is listed in cfg's ignored_functions; arguments to such calls are
+// never reported, letting a package allow-list its own duration constructors.
+func isIgnoredCall(cfg Config, call *ast.CallExpr) bool {
+	name := types.ExprString(call.Fun)
+	for _, ignored := range cfg.IgnoredFunctions {
+		if name == ignored {
+			return true
+		}
+	}
+	return false
 }
 
-func usesIntOrUntypedConstants(ti *types.Info, e ast.Expr) bool {
+// buildWrapConversionFix suggests wrapping expr as `time.Duration(expr)`.
+func buildWrapConversionFix(expr ast.Expr) analysis.SuggestedFix {
+	return analysis.SuggestedFix{
+		Message: "convert to time.Duration",
+		TextEdits: []analysis.TextEdit{
+			{Pos: expr.Pos(), End: expr.Pos(), NewText: []byte("time.Duration(")},
+			{Pos: expr.End(), End: expr.End(), NewText: []byte(")")},
+		},
+	}
+}
+
+// buildMultiplyFix suggests multiplying expr by the given duration unit, e.g.
+// `(expr) * time.Second`.
+func buildMultiplyFix(expr ast.Expr, unit string) analysis.SuggestedFix {
+	return analysis.SuggestedFix{
+		Message: fmt.Sprintf("multiply by %s", unit),
+		TextEdits: []analysis.TextEdit{
+			{Pos: expr.Pos(), End: expr.Pos(), NewText: []byte("(")},
+			{Pos: expr.End(), End: expr.End(), NewText: []byte(") * " + unit)},
+		},
+	}
+}
+
+func usesIntOrUntypedConstants(pass *analysis.Pass, e ast.Expr) bool {
 	switch v := e.(type) {
 	case *ast.BasicLit: // ex: 1
 		return v.Value != "0"
 	case *ast.BinaryExpr:
 		switch v.Op {
 		case token.ADD, token.SUB: // ex: 1 + time.Seconds
-			return usesIntOrUntypedConstants(ti, v.X) || usesIntOrUntypedConstants(ti, v.Y)
+			return usesIntOrUntypedConstants(pass, v.X) || usesIntOrUntypedConstants(pass, v.Y)
 		case token.MUL: // ex: 1 * time.Seconds
-			return usesIntOrUntypedConstants(ti, v.X) && usesIntOrUntypedConstants(ti, v.Y)
+			return usesIntOrUntypedConstants(pass, v.X) && usesIntOrUntypedConstants(pass, v.Y)
 		}
 	case *ast.Ident: // ex: someIdentifier
-		return hasIntOrUntypedConstDeclaration(ti, v)
+		return hasIntOrUntypedConstDeclaration(pass.TypesInfo, v)
 	case *ast.ParenExpr:
-		return usesIntOrUntypedConstants(ti, v.X)
+		return usesIntOrUntypedConstants(pass, v.X)
+	case *ast.UnaryExpr: // ex: -1
+		if v.Op == token.SUB {
+			return usesIntOrUntypedConstants(pass, v.X)
+		}
+	case *ast.CallExpr: // ex: computeBackoff(3), where computeBackoff returns time.Duration
+		return usesImproperDurationFact(pass, v)
 	}
 	return false
 }
@@ -302,6 +605,11 @@ func usesIntOrUntypedConstants(ti *types.Info, e ast.Expr) bool {
 // `var Name = 123`, and `a := 123` declarations are already type-checked
 // by the compiler
 func hasIntOrUntypedConstDeclaration(ti *types.Info, identifier *ast.Ident) bool {
+	// Obj is frequently nil for identifiers resolved from an imported package's AST (it's
+	// only populated for the file(s) that declared them), so there's nothing to look up.
+	if identifier.Obj == nil {
+		return false
+	}
 	decl := identifier.Obj.Decl
 
 	// TODO: we could ignore `var` statements altogether
@@ -331,6 +639,11 @@ func hasIntOrUntypedConstDeclaration(ti *types.Info, identifier *ast.Ident) bool
 		panic("logic error: identifier not found in its declaration")
 	}
 
+	// a valueless `var` declaration (e.g. `var x int`) has nothing to type-check here
+	if nameIdx >= len(vSpec.Values) {
+		return true
+	}
+
 	// skip if the right-hand side is explicitly typed to time.Duration
 	vType := ti.TypeOf(vSpec.Values[nameIdx])
 	if vType.String() != "time.Duration" {
@@ -340,21 +653,33 @@ func hasIntOrUntypedConstDeclaration(ti *types.Info, identifier *ast.Ident) bool
 	return false
 }
 
-// isDurationConversion recognizes `time.Duration(10)` in order either to not report it at all or
-// to report it differently than other errors, depending on the value of `fForbidExplicitCast`
-func isDurationConversion(v *ast.CallExpr) bool {
-	se, ok := v.Fun.(*ast.SelectorExpr)
-	if !ok {
+// isDurationConversion recognizes a conversion to time.Duration or to any other recognized
+// duration type, such as `time.Duration(10)` or `Timeout(10)`, in order either to not
+// report it at all or to report it differently than other errors, depending on the value
+// of ForbidImproperConversions in the Config in effect for the file. The callee is resolved through `pass.TypesInfo.Uses`
+// rather than matched by name, so it works regardless of import aliasing and recognizes
+// conversions to user-defined duration types as well as `time.Duration` itself.
+func isDurationConversion(pass *analysis.Pass, cfg Config, userTypes map[string]bool, v *ast.CallExpr) bool {
+	if len(v.Args) != 1 {
+		// a conversion takes exactly one argument; if not, it's some other call
 		return false
 	}
-	if len(v.Args) != 1 {
-		// Duration conversion should take exactly one argument; if not, it's some other call
+
+	var ident *ast.Ident
+	switch fun := v.Fun.(type) {
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	case *ast.Ident:
+		ident = fun
+	default:
 		return false
 	}
 
-	// NOTE: we don't check the package name in the selector expression, as it
-	// could have been aliased to something else
-	return se.Sel.Name == "Duration"
+	typeName, ok := pass.TypesInfo.Uses[ident].(*types.TypeName)
+	if !ok {
+		return false
+	}
+	return isDurationType(pass, cfg, userTypes, typeName.Type())
 }
 
 // checks if a type is an integer (e.g. int64, int, int32, uint32)
@@ -368,10 +693,104 @@ func isIntegerType(typ types.Type) bool {
 	return false
 }
 
-// checks if a type is `time.Duration`
-func isDurationType(typ types.Type) bool {
+// checks if a type is `time.Duration`, one of cfg's extra_duration_types, one of the types
+// collected by collectUserDurationTypes for the current package (annotated or derived from
+// time.Duration), or a type recognized the same way by another package, surfaced here via
+// the durationTypeFact that package exported for it. pass may be nil to skip that last
+// check.
+func isDurationType(pass *analysis.Pass, cfg Config, userTypes map[string]bool, typ types.Type) bool {
 	if typ == nil {
 		return false
 	}
-	return typ.String() == "time.Duration"
+	if typ.String() == "time.Duration" {
+		return true
+	}
+	for _, extra := range cfg.ExtraDurationTypes {
+		if typ.String() == extra {
+			return true
+		}
+	}
+	if userTypes[typ.String()] {
+		return true
+	}
+	if pass == nil {
+		return false
+	}
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return false
+	}
+	var fact durationTypeFact
+	return pass.ImportObjectFact(named.Obj(), &fact)
+}
+
+// hasDurationDocComment reports whether doc contains a `//durationlint:duration` line. It
+// checks doc.List directly rather than doc.Text(), because Text() drops lines shaped like
+// a compiler directive (a "key:value" comment with no space after "//", which is exactly
+// this marker's own shape) before a caller ever sees them.
+func hasDurationDocComment(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		line := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if line == durationDocComment {
+			return true
+		}
+	}
+	return false
+}
+
+// collectUserDurationTypes walks every TypeSpec in the package looking for named integer
+// types, with an underlying type of int64, that should be treated as time.Duration: those
+// marked with a `//durationlint:duration` doc comment, and those declared as `type X
+// time.Duration`. It returns a set of their (package-qualified) type strings, matching the
+// format `typ.String()` produces, so it can be consulted the same way as
+// Config.ExtraDurationTypes. It also exports a durationTypeFact for each one found, so a
+// downstream package importing the type (e.g. through an aliased import) recognizes it via
+// isDurationType's fact lookup even though it never sees this package's doc comments.
+func collectUserDurationTypes(pass *analysis.Pass) map[string]bool {
+	userTypes := map[string]bool{}
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			genDecl, ok := node.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				return true
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+
+				typeName, ok := pass.TypesInfo.Defs[typeSpec.Name].(*types.TypeName)
+				if !ok {
+					continue
+				}
+				named, ok := typeName.Type().(*types.Named)
+				if !ok {
+					continue
+				}
+				underlying, ok := named.Underlying().(*types.Basic)
+				if !ok || underlying.Kind() != types.Int64 {
+					continue
+				}
+
+				doc := typeSpec.Doc
+				if doc == nil && len(genDecl.Specs) == 1 {
+					doc = genDecl.Doc
+				}
+				isAnnotated := hasDurationDocComment(doc)
+
+				isDerivedFromDuration := pass.TypesInfo.TypeOf(typeSpec.Type).String() == "time.Duration"
+
+				if isAnnotated || isDerivedFromDuration {
+					userTypes[named.String()] = true
+					pass.ExportObjectFact(typeName, &durationTypeFact{})
+				}
+			}
+			return true
+		})
+	}
+	return userTypes
 }
@@ -19,9 +19,31 @@ func TestDefaultFlags(t *testing.T) {
 	analysistest.Run(t, testdata, Analyzer, "p1")
 }
 
+func TestUserDurationTypes(t *testing.T) {
+	resetFlags()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata")
+	analysistest.Run(t, testdata, Analyzer, "user_duration_types")
+}
+
+func TestCrossPackageFact(t *testing.T) {
+	resetFlags()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata")
+	analysistest.Run(t, testdata, Analyzer, "cross_package_fact")
+}
+
 func TestForbidImproperDurationConversions(t *testing.T) {
 	resetFlags()
-	fForbidExplicitConversion = true
+	fForbidImproperConversions = true
 	wd, err := os.Getwd()
 	if err != nil {
 		t.Fatalf("Failed to get wd: %s", err)
@@ -31,3 +53,45 @@ func TestForbidImproperDurationConversions(t *testing.T) {
 	analysistest.Run(t, testdata, Analyzer, "forbid_improper_conversion")
 
 }
+
+func TestReturns(t *testing.T) {
+	resetFlags()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata")
+	analysistest.Run(t, testdata, Analyzer, "returns")
+}
+
+// TestSSAMode runs with -mode=ssa, which replaces the syntactic
+// improperDurationContextStack walk with the SSA-based taint walk in ssa.go; it exercises
+// conversions that escape across multiple statements, which the AST-based walk can't see
+// through.
+func TestSSAMode(t *testing.T) {
+	resetFlags()
+	fMode = modeSSA
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata")
+	analysistest.Run(t, testdata, Analyzer, "ssa_mode")
+}
+
+// TestSuggestedFixes runs with -forbid-improper-conversions set, which keeps every
+// diagnostic down to a single suggested fix; analysistest.RunWithSuggestedFixes can only
+// apply an unambiguous fix per diagnostic and compare the result against a .golden file.
+func TestSuggestedFixes(t *testing.T) {
+	resetFlags()
+	fForbidImproperConversions = true
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata")
+	analysistest.RunWithSuggestedFixes(t, testdata, Analyzer, "suggested_fix")
+}
@@ -0,0 +1,113 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ConfigFileName is the name of the per-package configuration file consulted by run,
+// following the model of honnef.co/go/tools/config: each analyzed file's directory is
+// walked upward to the filesystem root, and every durationlint.conf found along the way
+// is merged into the result, with configs closer to the file overriding their ancestors'.
+const ConfigFileName = "durationlint.conf"
+
+// Config holds the per-package settings that durationlint.conf can override. Its first
+// three fields mirror the -forbid-improper-conversions, -default-unit and -duration-types
+// flags registered by registerFlags; IgnoredFunctions has no flag equivalent.
+type Config struct {
+	ForbidImproperConversions *bool    `toml:"forbid_improper_conversions"`
+	DefaultUnit               *string  `toml:"default_unit"`
+	ExtraDurationTypes        []string `toml:"extra_duration_types"`
+	IgnoredFunctions          []string `toml:"ignored_functions"`
+}
+
+// defaultConfig returns the configuration implied by the analyzer's flags, forming the
+// root of the merge chain for packages with no durationlint.conf of their own.
+func defaultConfig() Config {
+	forbidImproperConversions := fForbidImproperConversions
+	defaultUnit := fDefaultUnit
+	return Config{
+		ForbidImproperConversions: &forbidImproperConversions,
+		DefaultUnit:               &defaultUnit,
+		ExtraDurationTypes:        fDurationTypes,
+	}
+}
+
+// merge overlays child's explicitly-set fields onto c and returns the result; fields left
+// unset (nil, or an empty slice) in child fall back to c's value.
+func (c Config) merge(child Config) Config {
+	merged := c
+	if child.ForbidImproperConversions != nil {
+		merged.ForbidImproperConversions = child.ForbidImproperConversions
+	}
+	if child.DefaultUnit != nil {
+		merged.DefaultUnit = child.DefaultUnit
+	}
+	if len(child.ExtraDurationTypes) > 0 {
+		merged.ExtraDurationTypes = child.ExtraDurationTypes
+	}
+	if len(child.IgnoredFunctions) > 0 {
+		merged.IgnoredFunctions = child.IgnoredFunctions
+	}
+	return merged
+}
+
+// configCache memoizes the resolved config per directory, since many files in the same
+// package (and many packages under the same ancestor) would otherwise re-read and
+// re-merge the same durationlint.conf files. It's guarded by configCacheMu because
+// go/analysis runs a package's analyzers concurrently with its dependencies', so
+// configForDir can be called for different directories from different goroutines at once.
+var (
+	configCacheMu sync.Mutex
+	configCache   = map[string]Config{}
+)
+
+// configForDir resolves the effective Config for dir by walking up to the filesystem
+// root and merging each ancestor's durationlint.conf, from outermost to innermost, on top
+// of defaultConfig.
+func configForDir(dir string) Config {
+	configCacheMu.Lock()
+	cached, ok := configCache[dir]
+	configCacheMu.Unlock()
+	if ok {
+		return cached
+	}
+
+	var ancestors []string
+	for d := dir; ; {
+		ancestors = append(ancestors, d)
+		parent := filepath.Dir(d)
+		if parent == d {
+			break
+		}
+		d = parent
+	}
+
+	resolved := defaultConfig()
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		resolved = resolved.merge(loadConfigFile(ancestors[i]))
+	}
+
+	configCacheMu.Lock()
+	configCache[dir] = resolved
+	configCacheMu.Unlock()
+	return resolved
+}
+
+// loadConfigFile reads durationlint.conf from dir, returning a zero Config if the file is
+// absent or malformed; a malformed file is treated the same as a missing one rather than
+// failing the whole analysis run.
+func loadConfigFile(dir string) Config {
+	var cfg Config
+	path := filepath.Join(dir, ConfigFileName)
+	if _, err := os.Stat(path); err != nil {
+		return cfg
+	}
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		return Config{}
+	}
+	return cfg
+}
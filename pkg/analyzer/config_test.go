@@ -0,0 +1,59 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigForDirMergesAncestors(t *testing.T) {
+	resetFlags()
+
+	root := t.TempDir()
+	child := filepath.Join(root, "child")
+	if err := os.Mkdir(child, 0o755); err != nil {
+		t.Fatalf("failed to create child dir: %s", err)
+	}
+
+	writeConfig(t, root, `
+forbid_improper_conversions = true
+ignored_functions = ["time.Duration"]
+`)
+	writeConfig(t, child, `
+default_unit = "time.Minute"
+`)
+
+	cfg := configForDir(child)
+
+	if cfg.ForbidImproperConversions == nil || !*cfg.ForbidImproperConversions {
+		t.Errorf("expected forbid_improper_conversions inherited from parent to be true")
+	}
+	if cfg.DefaultUnit == nil || *cfg.DefaultUnit != "time.Minute" {
+		t.Errorf("expected default_unit overridden by child, got %v", cfg.DefaultUnit)
+	}
+	if len(cfg.IgnoredFunctions) != 1 || cfg.IgnoredFunctions[0] != "time.Duration" {
+		t.Errorf("expected ignored_functions inherited from parent, got %v", cfg.IgnoredFunctions)
+	}
+}
+
+func TestConfigForDirDefaultsWithoutConfigFile(t *testing.T) {
+	resetFlags()
+
+	dir := t.TempDir()
+	cfg := configForDir(dir)
+
+	if cfg.ForbidImproperConversions == nil || *cfg.ForbidImproperConversions != fForbidImproperConversions {
+		t.Errorf("expected ForbidImproperConversions to default to the flag value")
+	}
+	if cfg.DefaultUnit == nil || *cfg.DefaultUnit != defaultUnitDefault {
+		t.Errorf("expected DefaultUnit to default to %q, got %v", defaultUnitDefault, cfg.DefaultUnit)
+	}
+}
+
+func writeConfig(t *testing.T, dir string, contents string) {
+	t.Helper()
+	path := filepath.Join(dir, ConfigFileName)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %s", path, err)
+	}
+}
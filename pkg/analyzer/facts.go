@@ -0,0 +1,186 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// durationFact summarizes, for a function whose single result is a recognized duration
+// type, whether calling it launders an untyped integer literal or constant into a
+// duration without multiplying it by a proper unit. It lets checkAssignment and
+// checkArgument see through a call like `computeBackoff(3)` to the unmultiplied
+// `time.Duration(...)` conversion it returns, without re-walking the callee's body at every
+// call site.
+//
+// Exactly one of Proper, Improper, or a nonzero ParamMask is authoritative for a given
+// function; exportDurationFact derives them from a simplified walk of the function's
+// return statements.
+type durationFact struct {
+	// Proper is set when no return path returns a bare `time.Duration(...)` conversion.
+	// Every other return shape counts as proper, including a multiplied conversion, a
+	// duration variable, another call, and an untyped literal such as `return 10`.
+	Proper bool
+	// Improper is set when at least one return path returns an unmultiplied
+	// `time.Duration(intExpr)` conversion whose argument is not a parameter, independent
+	// of the arguments passed at any call site.
+	Improper bool
+	// ParamMask has bit i set when parameter i, on some return path, flows directly and
+	// unmultiplied into a `time.Duration(...)` conversion. Whether a given call is
+	// improper then depends on whether the actual argument at that call site is itself
+	// an untyped int/constant expression.
+	ParamMask uint64
+}
+
+func (*durationFact) AFact() {}
+
+func (f *durationFact) String() string {
+	return fmt.Sprintf("durationFact{Proper:%t Improper:%t ParamMask:%b}", f.Proper, f.Improper, f.ParamMask)
+}
+
+// durationTypeFact marks a named type as duration-like, the same way collectUserDurationTypes
+// marks one locally: because it's annotated with a `//durationlint:duration` doc comment or
+// declared as `type X time.Duration`. Exporting it lets isDurationType recognize the type
+// through an aliased import of the package that declared it, without re-parsing that
+// package's source for the doc comment or declaration shape.
+type durationTypeFact struct{}
+
+func (*durationTypeFact) AFact() {}
+
+func (*durationTypeFact) String() string { return "durationTypeFact" }
+
+// exportDurationFact computes and exports decl's durationFact, if decl has exactly one
+// result and that result is a recognized duration type. Functions with no return
+// statement, multiple results, or a non-duration result are left unannotated, so
+// usesImproperDurationFact's ImportObjectFact lookup simply misses for them.
+func exportDurationFact(pass *analysis.Pass, userTypes map[string]bool, decl *ast.FuncDecl) {
+	if decl.Body == nil || decl.Type.Results == nil || len(decl.Type.Results.List) != 1 {
+		return
+	}
+	resultType := pass.TypesInfo.TypeOf(decl.Type.Results.List[0].Type)
+	if !isDurationType(pass, Config{}, userTypes, resultType) {
+		return
+	}
+
+	fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return
+	}
+
+	paramIndex := map[*types.Var]int{}
+	if decl.Type.Params != nil {
+		idx := 0
+		for _, field := range decl.Type.Params.List {
+			for _, name := range field.Names {
+				if obj, ok := pass.TypesInfo.Defs[name].(*types.Var); ok {
+					paramIndex[obj] = idx
+				}
+				idx++
+			}
+		}
+	}
+
+	fact := durationFact{Proper: true}
+	hasReturn := false
+	ast.Inspect(decl.Body, func(node ast.Node) bool {
+		ret, ok := node.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			return true
+		}
+		hasReturn = true
+		classifyReturnExpr(pass, paramIndex, ret.Results[0], &fact)
+		return true
+	})
+	if !hasReturn {
+		return
+	}
+
+	pass.ExportObjectFact(fn, &fact)
+}
+
+// classifyReturnExpr updates fact for a single return expression. Only a bare
+// `time.Duration(arg)`-shaped conversion can make a return path improper; anything else
+// (already multiplied by a unit, a plain duration variable, another call, ...) is left as
+// the zero-risk "proper" default, mirroring improperDurationContextStack's own treatment
+// of multiplication as cleansing.
+func classifyReturnExpr(pass *analysis.Pass, paramIndex map[*types.Var]int, expr ast.Expr, fact *durationFact) {
+	call, ok := unwrapParens(expr).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	if !isDurationConversion(pass, Config{}, nil, call) {
+		return
+	}
+
+	arg := call.Args[0]
+	if ident, ok := unwrapParens(arg).(*ast.Ident); ok {
+		if v, ok := pass.TypesInfo.Uses[ident].(*types.Var); ok {
+			if idx, ok := paramIndex[v]; ok {
+				fact.ParamMask |= 1 << uint(idx)
+				fact.Proper = false
+				return
+			}
+		}
+	}
+
+	if usesIntOrUntypedConstants(pass, arg) || isIntegerType(pass.TypesInfo.TypeOf(arg)) {
+		fact.Improper = true
+		fact.Proper = false
+	}
+}
+
+func unwrapParens(expr ast.Expr) ast.Expr {
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			return expr
+		}
+		expr = paren.X
+	}
+}
+
+// usesImproperDurationFact reports whether call invokes a function whose durationFact
+// says it returns an improper duration, either unconditionally (Improper) or because the
+// actual argument at this call site lands on a parameter in ParamMask and is itself an
+// untyped int/constant expression.
+func usesImproperDurationFact(pass *analysis.Pass, call *ast.CallExpr) bool {
+	fn := calleeFunc(pass, call.Fun)
+	if fn == nil {
+		return false
+	}
+
+	var fact durationFact
+	if !pass.ImportObjectFact(fn, &fact) {
+		return false
+	}
+	if fact.Improper {
+		return true
+	}
+	if fact.Proper {
+		return false
+	}
+	for i, arg := range call.Args {
+		if fact.ParamMask&(1<<uint(i)) != 0 && usesIntOrUntypedConstants(pass, arg) {
+			return true
+		}
+	}
+	return false
+}
+
+// calleeFunc resolves a call's callee to the *types.Func it refers to, whether called
+// directly (`f(...)`) or through a package-qualified name (`pkg.F(...)`).
+func calleeFunc(pass *analysis.Pass, fun ast.Expr) *types.Func {
+	var ident *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		ident = f
+	case *ast.SelectorExpr:
+		ident = f.Sel
+	default:
+		return nil
+	}
+	fn, _ := pass.TypesInfo.Uses[ident].(*types.Func)
+	return fn
+}
@@ -0,0 +1,136 @@
+package analyzer
+
+import (
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+)
+
+// runSSA implements the `-mode=ssa` alternative to the syntactic
+// improperDurationContextStack walk. It finds every integer-to-duration *ssa.Convert in
+// the package's SSA form and performs a forward taint walk over its uses, flagging any
+// tainted value that escapes the function via a return, a store into a duration-typed
+// location, or a duration-typed call argument, without first being multiplied by a proper
+// duration. Because it tracks data flow rather than syntax, it treats a conversion that is
+// multiplied by a unit in a later statement, like `x := time.Duration(n); y := x *
+// time.Second`, as proper and does not report it, which the AST-based walk cannot see
+// through.
+func runSSA(pass *analysis.Pass) (interface{}, error) {
+	ssaInput := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+	for _, fn := range ssaInput.SrcFuncs {
+		checkSSAFunc(pass, fn)
+	}
+	return nil, nil
+}
+
+// checkSSAFunc runs the taint walk over a single function's SSA form.
+func checkSSAFunc(pass *analysis.Pass, fn *ssa.Function) {
+	// tainted maps each tainted ssa.Value to the position of the *ssa.Convert that
+	// introduced the taint, so diagnostics point at the original conversion rather than
+	// wherever it happened to escape.
+	tainted := map[ssa.Value]token.Pos{}
+
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			convert, ok := instr.(*ssa.Convert)
+			if !ok {
+				continue
+			}
+			if !isDurationType(pass, Config{}, nil, convert.Type()) {
+				continue
+			}
+			if !isIntegerType(convert.X.Type()) {
+				continue
+			}
+			tainted[convert] = convert.Pos()
+		}
+	}
+	if len(tainted) == 0 {
+		return
+	}
+
+	propagateSSATaint(pass, tainted)
+
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			switch v := instr.(type) {
+			case *ssa.Return:
+				for _, result := range v.Results {
+					if pos, ok := tainted[result]; ok {
+						reportSSATaint(pass, pos)
+					}
+				}
+			case *ssa.Store:
+				ptr, ok := v.Addr.Type().Underlying().(*types.Pointer)
+				if ok && isDurationType(pass, Config{}, nil, ptr.Elem()) {
+					if pos, ok := tainted[v.Val]; ok {
+						reportSSATaint(pass, pos)
+					}
+				}
+			case ssa.CallInstruction:
+				for _, arg := range v.Common().Args {
+					if !isDurationType(pass, Config{}, nil, arg.Type()) {
+						continue
+					}
+					if pos, ok := tainted[arg]; ok {
+						reportSSATaint(pass, pos)
+					}
+				}
+			}
+		}
+	}
+}
+
+// propagateSSATaint grows tainted to a fixed point by following each tainted value's uses.
+// A *ssa.BinOp multiplication whose other operand is a proper duration cleanses the taint,
+// per improperDurationContext's own treatment of multiplication elsewhere in this package;
+// every other use (including ssa.Phi, which simply becomes another tainted value) keeps
+// propagating it.
+func propagateSSATaint(pass *analysis.Pass, tainted map[ssa.Value]token.Pos) {
+	for changed := true; changed; {
+		changed = false
+		for value, pos := range tainted {
+			refs := value.Referrers()
+			if refs == nil {
+				continue
+			}
+			for _, instr := range *refs {
+				if isCleansingMultiplication(pass, instr, value) {
+					continue
+				}
+				result, ok := instr.(ssa.Value)
+				if !ok {
+					continue
+				}
+				if _, ok := tainted[result]; !ok {
+					tainted[result] = pos
+					changed = true
+				}
+			}
+		}
+	}
+}
+
+// isCleansingMultiplication reports whether instr multiplies value by an operand whose
+// type is itself a proper duration, resolving the taint instead of propagating it.
+func isCleansingMultiplication(pass *analysis.Pass, instr ssa.Instruction, value ssa.Value) bool {
+	binOp, ok := instr.(*ssa.BinOp)
+	if !ok || binOp.Op != token.MUL {
+		return false
+	}
+	other := binOp.Y
+	if binOp.Y == value {
+		other = binOp.X
+	}
+	return isDurationType(pass, Config{}, nil, other.Type())
+}
+
+func reportSSATaint(pass *analysis.Pass, pos token.Pos) {
+	pass.Report(analysis.Diagnostic{
+		Pos:     pos,
+		Message: "integer converted to time.Duration reaches a duration-typed return, store, or call without a unit multiplication",
+	})
+}
@@ -0,0 +1,19 @@
+package sub
+
+import "time"
+
+// ProperBackoff, ImproperBackoff, and ParamBackoff are declared in a separate package so the
+// durationFact exported for each can be exercised through ImportObjectFact across a real
+// package boundary, rather than only within the package that defines them.
+
+func ProperBackoff(n int) time.Duration { // want ProperBackoff:`durationFact{Proper:true Improper:false ParamMask:0}`
+	return time.Duration(n) * time.Second
+}
+
+func ImproperBackoff() time.Duration { // want ImproperBackoff:`durationFact{Proper:false Improper:true ParamMask:0}`
+	return time.Duration(10)
+}
+
+func ParamBackoff(n int) time.Duration { // want ParamBackoff:`durationFact{Proper:false Improper:false ParamMask:1}`
+	return time.Duration(n)
+}
@@ -0,0 +1,47 @@
+package cross_package_fact
+
+import (
+	"time"
+
+	"cross_package_fact/sub"
+)
+
+func properBackoff(n int) time.Duration { // want properBackoff:`durationFact{Proper:true Improper:false ParamMask:0}`
+	return time.Duration(n) * time.Second
+}
+
+func improperBackoff() time.Duration { // want improperBackoff:`durationFact{Proper:false Improper:true ParamMask:0}`
+	return time.Duration(10)
+}
+
+func paramBackoff(n int) time.Duration { // want paramBackoff:`durationFact{Proper:false Improper:false ParamMask:1}`
+	return time.Duration(n)
+}
+
+func TestFacts(n int) {
+	var d time.Duration
+
+	d = properBackoff(3) // non suspicious: properBackoff always multiplies by a unit
+
+	d = improperBackoff() // want `untyped constant in time.Duration assignment`
+
+	d = paramBackoff(5) // want `untyped constant in time.Duration assignment`
+	d = paramBackoff(0) // non suspicious: literal 0 is always allowed
+	d = paramBackoff(n) // non suspicious: n is an ordinary int parameter, not a literal or constant
+
+	_ = d
+}
+
+func TestImportedFacts(n int) {
+	var d time.Duration
+
+	d = sub.ProperBackoff(3) // non suspicious: ProperBackoff always multiplies by a unit
+
+	d = sub.ImproperBackoff() // want `untyped constant in time.Duration assignment`
+
+	d = sub.ParamBackoff(5) // want `untyped constant in time.Duration assignment`
+	d = sub.ParamBackoff(0) // non suspicious: literal 0 is always allowed
+	d = sub.ParamBackoff(n) // non suspicious: n is an ordinary int parameter, not a literal or constant
+
+	_ = d
+}
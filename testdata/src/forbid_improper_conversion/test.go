@@ -17,6 +17,10 @@ type CustomDuration int
 
 const customDurationConst CustomDuration = 10
 
+// Timeout is inferred as duration-like because it's declared as `type Timeout time.Duration`,
+// so conversions to it are checked the same way as conversions to time.Duration itself.
+type Timeout time.Duration // want Timeout:`durationTypeFact`
+
 type TestStruct struct {
 	DurationField1 time.Duration
 	DurationField2 time.Duration
@@ -30,7 +34,7 @@ func returnsInteger() uint8 {
 	return 5
 }
 
-func returnsDuration(integer int) time.Duration {
+func returnsDuration(integer int) time.Duration { // want returnsDuration:`durationFact{Proper:true Improper:false ParamMask:0}`
 	return time.Duration(integer) * time.Second
 }
 
@@ -66,4 +70,7 @@ func TestDurationConversionErrors() {
 	acceptsDuration(time.Duration(returnsInteger())) // want `converting integer via time.Duration.. without multiplication by proper duration`
 	acceptsDuration(time.Duration(predefindInt))     // want `converting integer via time.Duration.. without multiplication by proper duration`
 	acceptsDuration(time.Duration(10))               // want `converting integer via time.Duration.. without multiplication by proper duration`
+
+	_ = Timeout(5 * time.Second) // non suspicious
+	_ = Timeout(10)              // want `converting integer via time.Duration.. without multiplication by proper duration`
 }
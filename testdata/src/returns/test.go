@@ -0,0 +1,53 @@
+package returns
+
+import "time"
+
+func f() time.Duration { // want f:`durationFact{Proper:true Improper:false ParamMask:0}`
+	return 10 // want `untyped constant in time.Duration assignment`
+}
+
+func properReturn() time.Duration { // want properReturn:`durationFact{Proper:true Improper:false ParamMask:0}`
+	return 10 * time.Second // non suspicious: multiplied by a proper unit
+}
+
+func namedResult() (d time.Duration) {
+	d = 5 // want `untyped constant in time.Duration assignment`
+	return
+}
+
+func multiResult() (int, time.Duration) {
+	return 1, 10 // want `untyped constant in time.Duration assignment`
+}
+
+func sends(ch chan time.Duration) {
+	ch <- 5           // want `untyped constant in time.Duration assignment`
+	ch <- time.Second // non suspicious: already a proper duration
+}
+
+func mapLiteral() map[string]time.Duration {
+	return map[string]time.Duration{
+		"x": 3, // want `untyped constant in time.Duration assignment`
+		"y": time.Second,
+	}
+}
+
+func sliceLiteral() []time.Duration {
+	return []time.Duration{1, 2, 3} // want `untyped constant in time.Duration assignment` `untyped constant in time.Duration assignment` `untyped constant in time.Duration assignment`
+}
+
+func indexedAssignment(s []time.Duration) {
+	s[0] = 5 // want `untyped constant in time.Duration assignment`
+}
+
+func durationKeyedMap() map[time.Duration]string {
+	return map[time.Duration]string{
+		5:           "x", // want `untyped constant in time.Duration assignment`
+		time.Second: "y",
+	}
+}
+
+func durationKeyedAndValuedMap() map[time.Duration]time.Duration {
+	return map[time.Duration]time.Duration{
+		1: 2, // want `untyped constant in time.Duration assignment` `untyped constant in time.Duration assignment`
+	}
+}
@@ -0,0 +1,36 @@
+package ssa_mode
+
+import "time"
+
+// properAcrossStatements multiplies the conversion by a proper unit in a later statement;
+// the AST walk cannot see this because the conversion and the multiplication are different
+// statements, but the SSA-based walk follows the value through both.
+func properAcrossStatements(n int) time.Duration {
+	d := time.Duration(n)
+	d = d * time.Second
+	return d
+}
+
+// improperAcrossStatements never multiplies the conversion by a unit before it escapes via
+// the return.
+func improperAcrossStatements(n int) time.Duration {
+	d := time.Duration(n) // want `integer converted to time.Duration reaches a duration-typed return, store, or call without a unit multiplication`
+	return d
+}
+
+var sink time.Duration
+
+// improperIntoGlobal stores the unmultiplied conversion into a package-level duration
+// variable instead of returning it.
+func improperIntoGlobal(n int) {
+	sink = time.Duration(n) // want `integer converted to time.Duration reaches a duration-typed return, store, or call without a unit multiplication`
+}
+
+func sleep(d time.Duration) {}
+
+// improperIntoCall passes the unmultiplied conversion as a duration-typed argument to
+// another function.
+func improperIntoCall(n int) {
+	d := time.Duration(n) // want `integer converted to time.Duration reaches a duration-typed return, store, or call without a unit multiplication`
+	sleep(d)
+}
@@ -0,0 +1,24 @@
+package suggested_fix
+
+import (
+	"time"
+)
+
+const untypedConst = 30
+
+func TestAssignmentFix() {
+	var a time.Duration
+
+	a = 10           // want `untyped constant in time.Duration assignment`
+	a = untypedConst // want `untyped constant in time.Duration assignment`
+
+	_ = a
+}
+
+func TestArgumentFix() {
+	time.Sleep(10) // want `untyped constant in time.Duration argument`
+}
+
+func TestConversionFix() {
+	_ = time.Duration(10) // want `converting integer via time.Duration.. without multiplication by proper duration`
+}
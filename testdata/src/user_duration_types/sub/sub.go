@@ -0,0 +1,7 @@
+package sub
+
+import "time"
+
+// Timeout is declared in a separate package so the analyzer's user-defined duration type
+// detection can be exercised through an aliased import.
+type Timeout time.Duration
@@ -0,0 +1,38 @@
+package user_duration_types
+
+import (
+	"time"
+
+	subAliased "user_duration_types/sub"
+)
+
+// Timeout is inferred as duration-like because it's declared as `type Timeout time.Duration`.
+type Timeout time.Duration // want Timeout:`durationTypeFact`
+
+//durationlint:duration
+type Interval int64 // want Interval:`durationTypeFact`
+
+func TestDerivedType() {
+	var t Timeout
+
+	t = Timeout(10 * time.Second) // non suspicious
+	t = Timeout(10)               // non suspicious: improper conversions aren't forbidden here
+	t = 10                        // want `untyped constant in time.Duration assignment`
+	_ = t
+}
+
+func TestAnnotatedType() {
+	var i Interval
+
+	i = Interval(5 * time.Second) // non suspicious
+	i = 5                         // want `untyped constant in time.Duration assignment`
+	_ = i
+}
+
+func TestAliasedImportedType() {
+	var st subAliased.Timeout
+
+	st = subAliased.Timeout(10 * time.Second) // non suspicious
+	st = 10                                   // want `untyped constant in time.Duration assignment`
+	_ = st
+}